@@ -0,0 +1,45 @@
+// Package core defines the fundamental value types shared by the
+// reader and the rest of sabre.
+package core
+
+import "fmt"
+
+// Value represents a Lisp value produced by the reader or evaluator.
+// Every concrete type in this package implements it.
+type Value interface {
+	String() string
+}
+
+// Position describes where in the source a value was read from. The
+// zero value represents an unknown position.
+type Position struct {
+	File   string
+	Line   int
+	Column int
+}
+
+// String returns a "file:line:column" representation, or the empty
+// string if the position is not known.
+func (p Position) String() string {
+	if p.File == "" && p.Line == 0 && p.Column == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s:%d:%d", p.File, p.Line, p.Column)
+}
+
+// Positioned is implemented by values that track where they were read
+// from.
+type Positioned interface {
+	Pos() Position
+}
+
+// IMeta is implemented by values that can carry metadata, such as the
+// map attached by the `^` reader macro.
+type IMeta interface {
+	// Meta returns the value's metadata, or nil if it has none.
+	Meta() *HashMap
+
+	// WithMeta returns a copy of the value with its metadata replaced
+	// by meta.
+	WithMeta(meta *HashMap) Value
+}
@@ -0,0 +1,204 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Nil represents the absence of a value, analogous to Clojure's nil.
+type Nil struct{}
+
+func (Nil) String() string { return "nil" }
+
+// Bool represents a boolean literal.
+type Bool bool
+
+func (b Bool) String() string {
+	if b {
+		return "true"
+	}
+	return "false"
+}
+
+// Int64 represents an integer literal.
+type Int64 int64
+
+func (i Int64) String() string { return fmt.Sprintf("%d", int64(i)) }
+
+// Float64 represents a floating point literal.
+type Float64 float64
+
+func (f Float64) String() string { return fmt.Sprintf("%g", float64(f)) }
+
+// String represents a string literal.
+type String string
+
+func (s String) String() string { return fmt.Sprintf("%q", string(s)) }
+
+// Character represents a single character literal (e.g., \a, \newline).
+type Character rune
+
+func (c Character) String() string { return fmt.Sprintf("\\%c", rune(c)) }
+
+// Keyword represents a keyword literal (e.g., :foo).
+type Keyword struct {
+	Value    string
+	Metadata *HashMap
+}
+
+func (k Keyword) String() string { return ":" + k.Value }
+
+// Meta returns the keyword's metadata, or nil if it has none.
+func (k Keyword) Meta() *HashMap { return k.Metadata }
+
+// WithMeta returns a copy of k with its metadata replaced by meta.
+func (k Keyword) WithMeta(meta *HashMap) Value {
+	k.Metadata = meta
+	return k
+}
+
+// Symbol represents a symbolic identifier.
+type Symbol struct {
+	Value    string
+	Position Position
+	Metadata *HashMap
+}
+
+func (s Symbol) String() string { return s.Value }
+
+// Pos returns the position the symbol was read from.
+func (s Symbol) Pos() Position { return s.Position }
+
+// Meta returns the symbol's metadata, or nil if it has none.
+func (s Symbol) Meta() *HashMap { return s.Metadata }
+
+// WithMeta returns a copy of s with its metadata replaced by meta.
+func (s Symbol) WithMeta(meta *HashMap) Value {
+	s.Metadata = meta
+	return s
+}
+
+// UnquoteSplice represents a `~@x` form read inside a syntax-quote. It
+// is not a value in its own right: the enclosing list/vector builder
+// consumes it and inlines x's elements in its place.
+type UnquoteSplice struct {
+	Value    Value
+	Position Position
+}
+
+func (u UnquoteSplice) String() string { return "~@" + u.Value.String() }
+
+// Pos returns the position the splice was read from.
+func (u UnquoteSplice) Pos() Position { return u.Position }
+
+// Module represents a sequence of top-level forms read from a single
+// source, as returned by Reader.All().
+type Module []Value
+
+func (m Module) String() string {
+	parts := make([]string, len(m))
+	for i, v := range m {
+		parts[i] = v.String()
+	}
+	return strings.Join(parts, "\n")
+}
+
+// List represents a Lisp list form, e.g. (a b c).
+type List struct {
+	Values   []Value
+	Position Position
+	Metadata *HashMap
+}
+
+func (l *List) String() string { return "(" + joinValues(l.Values) + ")" }
+
+// Pos returns the position the list was read from.
+func (l *List) Pos() Position { return l.Position }
+
+// Meta returns the list's metadata, or nil if it has none.
+func (l *List) Meta() *HashMap { return l.Metadata }
+
+// WithMeta returns a copy of l with its metadata replaced by meta.
+func (l *List) WithMeta(meta *HashMap) Value {
+	clone := *l
+	clone.Metadata = meta
+	return &clone
+}
+
+// Vector represents a vector form, e.g. [a b c].
+type Vector struct {
+	Values   []Value
+	Position Position
+	Metadata *HashMap
+}
+
+func (v Vector) String() string { return "[" + joinValues(v.Values) + "]" }
+
+// Pos returns the position the vector was read from.
+func (v Vector) Pos() Position { return v.Position }
+
+// Meta returns the vector's metadata, or nil if it has none.
+func (v Vector) Meta() *HashMap { return v.Metadata }
+
+// WithMeta returns a copy of v with its metadata replaced by meta.
+func (v Vector) WithMeta(meta *HashMap) Value {
+	v.Metadata = meta
+	return v
+}
+
+// Set represents a set form, e.g. #{a b c}.
+type Set struct {
+	Values   []Value
+	Position Position
+	Metadata *HashMap
+}
+
+func (s Set) String() string { return "#{" + joinValues(s.Values) + "}" }
+
+// Pos returns the position the set was read from.
+func (s Set) Pos() Position { return s.Position }
+
+// Meta returns the set's metadata, or nil if it has none.
+func (s Set) Meta() *HashMap { return s.Metadata }
+
+// WithMeta returns a copy of s with its metadata replaced by meta.
+func (s Set) WithMeta(meta *HashMap) Value {
+	s.Metadata = meta
+	return s
+}
+
+// HashMap represents a map form, e.g. {:a 1 :b 2}.
+type HashMap struct {
+	Data     map[Value]Value
+	Position Position
+	Metadata *HashMap
+}
+
+func (m *HashMap) String() string {
+	parts := make([]string, 0, len(m.Data))
+	for k, v := range m.Data {
+		parts = append(parts, k.String()+" "+v.String())
+	}
+	return "{" + strings.Join(parts, ", ") + "}"
+}
+
+// Pos returns the position the map was read from.
+func (m *HashMap) Pos() Position { return m.Position }
+
+// Meta returns the map's metadata, or nil if it has none.
+func (m *HashMap) Meta() *HashMap { return m.Metadata }
+
+// WithMeta returns a copy of m with its metadata replaced by meta.
+func (m *HashMap) WithMeta(meta *HashMap) Value {
+	clone := *m
+	clone.Metadata = meta
+	return &clone
+}
+
+func joinValues(vs []Value) string {
+	parts := make([]string, len(vs))
+	for i, v := range vs {
+		parts[i] = v.String()
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,88 @@
+package reader
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spy16/sabre/core"
+)
+
+func TestReader_DataReader(t *testing.T) {
+	t.Run("InstTag", func(t *testing.T) {
+		rd := New(strings.NewReader(`#inst "2024-01-01T00:00:00Z"`))
+		rd.SetDataReader("inst", func(_ *Reader, form core.Value) (core.Value, error) {
+			s, ok := form.(core.String)
+			if !ok {
+				return nil, fmt.Errorf("#inst expects a string, got %T", form)
+			}
+			t, err := time.Parse(time.RFC3339, string(s))
+			if err != nil {
+				return nil, err
+			}
+			return core.String(t.Format(time.RFC3339)), nil
+		})
+
+		got, err := rd.One()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != core.String("2024-01-01T00:00:00Z") {
+			t.Errorf("got = %#v, want = %#v", got, core.String("2024-01-01T00:00:00Z"))
+		}
+	})
+
+	t.Run("UserRegisteredTag", func(t *testing.T) {
+		rd := New(strings.NewReader(`#upper "hello"`))
+		rd.SetDataReader("upper", func(_ *Reader, form core.Value) (core.Value, error) {
+			s, ok := form.(core.String)
+			if !ok {
+				return nil, fmt.Errorf("#upper expects a string, got %T", form)
+			}
+			return core.String(strings.ToUpper(string(s))), nil
+		})
+
+		got, err := rd.One()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != core.String("HELLO") {
+			t.Errorf("got = %#v, want = %#v", got, core.String("HELLO"))
+		}
+	})
+
+	t.Run("UnsetDataReader", func(t *testing.T) {
+		rd := New(strings.NewReader(`#upper "hello"`))
+		rd.SetDataReader("upper", func(_ *Reader, form core.Value) (core.Value, error) {
+			return form, nil
+		})
+		rd.UnsetDataReader("upper")
+
+		if _, err := rd.One(); err == nil {
+			t.Error("expected error for unregistered tag, got nil")
+		}
+	})
+
+	t.Run("UnknownTag", func(t *testing.T) {
+		rd := New(strings.NewReader(`#unknown-tag 123`))
+		if _, err := rd.One(); err == nil {
+			t.Error("expected error for unknown tag, got nil")
+		}
+	})
+
+	t.Run("SetLiteralStillWorks", func(t *testing.T) {
+		rd := New(strings.NewReader(`#{1 2 3}`))
+		got, err := rd.One()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		set, ok := got.(core.Set)
+		if !ok {
+			t.Fatalf("got = %#v, want core.Set", got)
+		}
+		if len(set.Values) != 3 {
+			t.Errorf("len(Values) = %d, want 3", len(set.Values))
+		}
+	})
+}
@@ -0,0 +1,125 @@
+package reader
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spy16/sabre/core"
+)
+
+func TestReader_Metadata(t *testing.T) {
+	executeReaderTests(t, []readerTestCase{
+		{
+			name: "KeywordShorthand",
+			src:  `^:foo x`,
+			want: core.Symbol{
+				Value:    "x",
+				Position: core.Position{File: "<string>", Line: 1, Column: 7},
+				Metadata: &core.HashMap{
+					Position: core.Position{File: "<string>", Line: 1, Column: 1},
+					Data:     map[core.Value]core.Value{core.Keyword{Value: "foo"}: core.Bool(true)},
+				},
+			},
+		},
+		{
+			name: "StringShorthand",
+			src:  `^"str" x`,
+			want: core.Symbol{
+				Value:    "x",
+				Position: core.Position{File: "<string>", Line: 1, Column: 8},
+				Metadata: &core.HashMap{
+					Position: core.Position{File: "<string>", Line: 1, Column: 1},
+					Data:     map[core.Value]core.Value{core.Keyword{Value: "tag"}: core.String("str")},
+				},
+			},
+		},
+		{
+			name: "SymbolShorthand",
+			src:  `^Foo x`,
+			want: core.Symbol{
+				Value:    "x",
+				Position: core.Position{File: "<string>", Line: 1, Column: 6},
+				Metadata: &core.HashMap{
+					Position: core.Position{File: "<string>", Line: 1, Column: 1},
+					Data: map[core.Value]core.Value{
+						core.Keyword{Value: "tag"}: core.Symbol{
+							Value:    "Foo",
+							Position: core.Position{File: "<string>", Line: 1, Column: 2},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "MapDirect",
+			src:  `^{:foo 1 :bar 2} x`,
+			want: core.Symbol{
+				Value:    "x",
+				Position: core.Position{File: "<string>", Line: 1, Column: 18},
+				Metadata: &core.HashMap{
+					Position: core.Position{File: "<string>", Line: 1, Column: 2},
+					Data: map[core.Value]core.Value{
+						core.Keyword{Value: "foo"}: core.Int64(1),
+						core.Keyword{Value: "bar"}: core.Int64(2),
+					},
+				},
+			},
+		},
+		{
+			name: "AttachesToList",
+			src:  `^:foo (a b)`,
+			want: &core.List{
+				Values: []core.Value{
+					core.Symbol{Value: "a", Position: core.Position{File: "<string>", Line: 1, Column: 8}},
+					core.Symbol{Value: "b", Position: core.Position{File: "<string>", Line: 1, Column: 10}},
+				},
+				Position: core.Position{File: "<string>", Line: 1, Column: 7},
+				Metadata: &core.HashMap{
+					Position: core.Position{File: "<string>", Line: 1, Column: 1},
+					Data:     map[core.Value]core.Value{core.Keyword{Value: "foo"}: core.Bool(true)},
+				},
+			},
+		},
+		{
+			name:    "InvalidMetaForm",
+			src:     `^1 x`,
+			wantErr: true,
+		},
+		{
+			name:    "TargetCannotCarryMeta",
+			src:     `^:foo 1`,
+			wantErr: true,
+		},
+	})
+}
+
+func TestReader_Metadata_StackedPrefixesMerge(t *testing.T) {
+	got, err := New(strings.NewReader(`^{:a 1 :shared :outer} ^{:b 2 :shared :inner} x`)).One()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sym, ok := got.(core.Symbol)
+	if !ok {
+		t.Fatalf("got = %#v, want core.Symbol", got)
+	}
+
+	meta := sym.Meta()
+	if meta == nil {
+		t.Fatal("expected metadata to be attached")
+	}
+
+	want := map[core.Value]core.Value{
+		core.Keyword{Value: "a"}:      core.Int64(1),
+		core.Keyword{Value: "b"}:      core.Int64(2),
+		core.Keyword{Value: "shared"}: core.Keyword{Value: "inner"},
+	}
+	if len(meta.Data) != len(want) {
+		t.Fatalf("meta = %#v, want %#v", meta.Data, want)
+	}
+	for k, v := range want {
+		if meta.Data[k] != v {
+			t.Errorf("meta[%s] = %#v, want %#v", k, meta.Data[k], v)
+		}
+	}
+}
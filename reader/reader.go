@@ -0,0 +1,1107 @@
+// Package reader implements a Lisp reader that turns a stream of runes
+// into sabre core.Value forms.
+package reader
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/spy16/sabre/core"
+)
+
+// errSkip is returned internally by macros (e.g. comments) that do not
+// produce a value of their own. One() retries the read when it sees
+// this error.
+var errSkip = errors.New("reader: skip")
+
+// ErrIncomplete is wrapped into any error returned while a form (list,
+// vector, string, etc.) is left open at EOF. Callers such as a REPL
+// can match it with errors.Is to tell "needs more input" apart from a
+// genuine syntax error or a clean end-of-stream (io.EOF).
+var ErrIncomplete = errors.New("reader: incomplete form")
+
+// Macro implements a reader macro triggered by a specific rune. init
+// is the rune that triggered the macro (already consumed).
+type Macro func(rd *Reader, init rune) (core.Value, error)
+
+// DataReaderFunc is invoked to convert the form following a `#tag` into
+// a core.Value.
+type DataReaderFunc func(rd *Reader, form core.Value) (core.Value, error)
+
+// Reader reads forms from an underlying io.Reader.
+type Reader struct {
+	File string
+
+	// Features controls which branches of a `#?(...)` reader
+	// conditional are read. New initializes it with "go" and
+	// "default" enabled.
+	Features map[string]bool
+
+	rs   io.RuneScanner
+	buf  []rune // runes read from rs but not yet compacted away
+	pos  int    // read cursor into buf
+	line int
+	col  int
+
+	// prevLine, prevCol hold line/col as of just before the last
+	// NextRune call, so unreadRune can restore them exactly.
+	prevLine, prevCol int
+
+	// snapshotPos, when snapshotted is true, is the buffer position of
+	// the oldest outstanding Snapshot; compact must not discard runes
+	// before it, since Restore may still need to rewind there.
+	snapshotPos int
+	snapshotted bool
+
+	macros      map[rune]Macro
+	dispatch    map[rune]Macro
+	dataReaders map[string]DataReaderFunc
+
+	gensymSeq int
+}
+
+// New creates a Reader that reads forms from r.
+func New(r io.Reader) *Reader {
+	rd := &Reader{
+		File:        fileName(r),
+		Features:    map[string]bool{"go": true, "default": true},
+		rs:          toRuneScanner(r),
+		line:        1,
+		col:         0,
+		macros:      map[rune]Macro{},
+		dispatch:    map[rune]Macro{},
+		dataReaders: map[string]DataReaderFunc{},
+	}
+
+	rd.SetMacro('^', metaMacro, false)
+	rd.SetMacro('\'', quoteMacro, false)
+	rd.SetMacro('`', syntaxQuoteMacro, false)
+	rd.SetMacro('~', unquoteMacro, false)
+	rd.SetMacro(';', commentMacro, false)
+	rd.SetMacro('"', stringMacro, false)
+	rd.SetMacro('\\', characterMacro, false)
+	rd.SetMacro('(', listMacro, false)
+	rd.SetMacro(')', closerMacro, false)
+	rd.SetMacro('[', vectorMacro, false)
+	rd.SetMacro(']', closerMacro, false)
+	rd.SetMacro('{', hashMapMacro, false)
+	rd.SetMacro('}', closerMacro, false)
+	rd.SetMacro('#', dispatchMacro, false)
+
+	rd.SetMacro('{', setDispatchMacro, true)
+	rd.SetMacro('?', readerConditionalMacro, true)
+
+	return rd
+}
+
+func fileName(r io.Reader) string {
+	switch v := r.(type) {
+	case *strings.Reader:
+		return "<string>"
+	case *bytes.Reader:
+		return "<bytes>"
+	case *os.File:
+		return v.Name()
+	default:
+		return "<unknown>"
+	}
+}
+
+func toRuneScanner(r io.Reader) io.RuneScanner {
+	if rs, ok := r.(io.RuneScanner); ok {
+		return rs
+	}
+	return bufio.NewReader(r)
+}
+
+// SetMacro registers fn as the macro triggered by init. If dispatch is
+// true, fn is registered as a dispatch macro, triggered when init
+// follows a '#'. Passing a nil fn removes any macro currently bound to
+// init (reverting dispatch macros to the default "read tag, invoke
+// data-reader" behavior).
+func (rd *Reader) SetMacro(init rune, fn Macro, dispatch bool) {
+	target := rd.macros
+	if dispatch {
+		target = rd.dispatch
+	}
+
+	if fn == nil {
+		delete(target, init)
+		return
+	}
+	target[init] = fn
+}
+
+// SetDataReader registers fn to handle `#tag form` tagged literals for
+// the given tag.
+func (rd *Reader) SetDataReader(tag string, fn DataReaderFunc) {
+	rd.dataReaders[tag] = fn
+}
+
+// UnsetDataReader removes any data-reader registered for tag.
+func (rd *Reader) UnsetDataReader(tag string) {
+	delete(rd.dataReaders, tag)
+}
+
+// SetFeature enables or disables name as a feature that `#?(...)`
+// reader conditionals can select on.
+func (rd *Reader) SetFeature(name string, on bool) {
+	if rd.Features == nil {
+		rd.Features = map[string]bool{}
+	}
+	rd.Features[name] = on
+}
+
+// NextRune reads and returns the next rune, tracking line/column
+// position. Runes are buffered internally (rather than relying on the
+// underlying io.Reader's own pushback) so that Snapshot/Restore can
+// rewind across more than one rune.
+func (rd *Reader) NextRune() (rune, error) {
+	if rd.pos >= len(rd.buf) {
+		r, _, err := rd.rs.ReadRune()
+		if err != nil {
+			return 0, err
+		}
+		rd.buf = append(rd.buf, r)
+	}
+
+	r := rd.buf[rd.pos]
+	rd.pos++
+
+	rd.prevLine, rd.prevCol = rd.line, rd.col
+	if r == '\n' {
+		rd.line++
+		rd.col = 0
+	} else {
+		rd.col++
+	}
+
+	return r, nil
+}
+
+// unreadRune undoes the most recent NextRune call, restoring pos and
+// the line/col it had advanced from. It only supports rewinding a
+// single rune; every call site pairs it with exactly one preceding
+// NextRune.
+func (rd *Reader) unreadRune() {
+	if rd.pos > 0 {
+		rd.pos--
+	}
+	rd.line, rd.col = rd.prevLine, rd.prevCol
+}
+
+// compact discards buffered runes already consumed, once a top-level
+// form has been fully read. It keeps memory bounded while streaming
+// through Next(). If a Snapshot is still outstanding, compaction stops
+// at its position instead, since Restore may need to rewind there.
+func (rd *Reader) compact() {
+	limit := rd.pos
+	if rd.snapshotted && rd.snapshotPos < limit {
+		limit = rd.snapshotPos
+	}
+	if limit == 0 {
+		return
+	}
+	rd.buf = append(rd.buf[:0], rd.buf[limit:]...)
+	rd.pos -= limit
+	if rd.snapshotted {
+		rd.snapshotPos -= limit
+	}
+}
+
+func (rd *Reader) position() core.Position {
+	return core.Position{File: rd.File, Line: rd.line, Column: rd.col}
+}
+
+// IsTerminal returns true if r terminates a symbol/number token: EOF,
+// whitespace, comma or a rune bound to a macro.
+func (rd *Reader) IsTerminal(r rune) bool {
+	if unicode.IsSpace(r) || r == ',' {
+		return true
+	}
+	_, ok := rd.macros[r]
+	return ok
+}
+
+// One reads and returns the next form. It returns io.EOF when the
+// stream is exhausted.
+func (rd *Reader) One() (core.Value, error) {
+	v, err := rd.readForm()
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := v.(condSplice); ok {
+		return nil, errors.New("reader: #?@ is only valid inside a list or vector")
+	}
+	return v, nil
+}
+
+// readForm reads the next form, retrying while a macro returns
+// errSkip. It returns a condSplice as-is and leaves rejecting it to
+// One; readDelimited reads through readOne instead and inlines
+// condSplice values itself.
+func (rd *Reader) readForm() (core.Value, error) {
+	for {
+		v, err := rd.readOne()
+		if err == errSkip {
+			continue
+		}
+		return v, err
+	}
+}
+
+func (rd *Reader) readOne() (core.Value, error) {
+	r, err := rd.skipSpaces()
+	if err != nil {
+		return nil, err
+	}
+
+	pos := rd.position()
+
+	if fn, ok := rd.macros[r]; ok {
+		v, err := fn(rd, r)
+		if err != nil {
+			return nil, err
+		}
+		return v, nil
+	}
+
+	return rd.readAtom(r, pos)
+}
+
+func (rd *Reader) skipSpaces() (rune, error) {
+	for {
+		r, err := rd.NextRune()
+		if err != nil {
+			return 0, err
+		}
+		if unicode.IsSpace(r) || r == ',' {
+			continue
+		}
+		return r, nil
+	}
+}
+
+// Next reads and returns the next top-level form, the same as One. In
+// addition it releases any input buffered for that form once it has
+// been fully read, so that pulling forms one at a time through Next
+// keeps memory bounded regardless of source size. It returns io.EOF
+// when the stream is exhausted.
+func (rd *Reader) Next() (core.Value, error) {
+	v, err := rd.One()
+	if err != nil {
+		return nil, err
+	}
+	rd.compact()
+	return v, nil
+}
+
+// All reads every remaining top-level form and returns them as a
+// core.Module.
+func (rd *Reader) All() (core.Value, error) {
+	var mod core.Module
+	for {
+		v, err := rd.Next()
+		if err == io.EOF {
+			return mod, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		mod = append(mod, v)
+	}
+}
+
+// Snapshot is an opaque capture of a Reader's position, returned by
+// Reader.Snapshot and consumed by Reader.Restore.
+type Snapshot struct {
+	pos       int
+	line, col int
+	gensymSeq int
+}
+
+// Snapshot captures the reader's current position so that a
+// speculative parse (e.g. a REPL checking whether a form is complete)
+// can be rolled back with Restore. While a Snapshot taken this way is
+// outstanding, and no Restore has been called since, Next() will not
+// compact past it, so it remains valid to restore to even across an
+// intervening completed Next() call.
+func (rd *Reader) Snapshot() Snapshot {
+	if !rd.snapshotted {
+		// Drop already-consumed runes first so the hold starts at the
+		// front of buf; compact then never shifts runes under s.pos.
+		rd.compact()
+	}
+	if !rd.snapshotted || rd.pos < rd.snapshotPos {
+		rd.snapshotPos = rd.pos
+	}
+	rd.snapshotted = true
+	return Snapshot{pos: rd.pos, line: rd.line, col: rd.col, gensymSeq: rd.gensymSeq}
+}
+
+// Restore rewinds the reader to the position captured by s, as if the
+// runes read since had never been consumed, and releases the hold on
+// buffer compaction. There is a single hold shared by all outstanding
+// Snapshots, so Restore releases it for every one of them, not only s.
+func (rd *Reader) Restore(s Snapshot) {
+	rd.pos = s.pos
+	rd.line = s.line
+	rd.col = s.col
+	rd.gensymSeq = s.gensymSeq
+	rd.snapshotted = false
+}
+
+func (rd *Reader) readAtom(init rune, pos core.Position) (core.Value, error) {
+	switch init {
+	case ':':
+		return rd.readKeyword(pos)
+	}
+
+	token, err := rd.readToken(init)
+	if err != nil {
+		return nil, err
+	}
+
+	switch token {
+	case "true":
+		return core.Bool(true), nil
+	case "false":
+		return core.Bool(false), nil
+	case "nil":
+		return core.Nil{}, nil
+	}
+
+	if looksLikeNumber(token) {
+		v, err := parseNumber(token)
+		if err != nil {
+			return nil, fmt.Errorf("reader: invalid number literal '%s' at %s: %v", token, pos, err)
+		}
+		return v, nil
+	}
+
+	return core.Symbol{Value: token, Position: pos}, nil
+}
+
+// looksLikeNumber reports whether token should be parsed as a number
+// literal: it starts with a digit, or a sign immediately followed by
+// a digit.
+func looksLikeNumber(token string) bool {
+	if token == "" {
+		return false
+	}
+	r := []rune(token)
+	if unicode.IsDigit(r[0]) {
+		return true
+	}
+	if (r[0] == '+' || r[0] == '-') && len(r) > 1 && unicode.IsDigit(r[1]) {
+		return true
+	}
+	return false
+}
+
+func (rd *Reader) readToken(init rune) (string, error) {
+	var sb strings.Builder
+	sb.WriteRune(init)
+
+	for {
+		r, err := rd.NextRune()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		// '#' only triggers dispatchMacro when it is the first rune of
+		// a form (handled by readOne before readToken is ever entered),
+		// so past the start of a token it's an ordinary trailing
+		// character, e.g. the auto-gensym suffix in `foo#`.
+		if r != '#' && rd.IsTerminal(r) {
+			rd.unreadRune()
+			break
+		}
+		sb.WriteRune(r)
+	}
+
+	return sb.String(), nil
+}
+
+func (rd *Reader) readKeyword(pos core.Position) (core.Value, error) {
+	token, err := rd.readToken(':')
+	if err != nil {
+		return nil, err
+	}
+	return core.Keyword{Value: strings.TrimPrefix(token, ":")}, nil
+}
+
+// metaMacro implements `^meta form`: it reads a metadata form, reads
+// the form that follows, and attaches the metadata to it. `^:foo x`
+// and `^"str" x` and `^sym x` are shorthand for `^{:foo true} x`,
+// `^{:tag "str"} x` and `^{:tag sym} x` respectively; `^{...} x` uses
+// the map as-is. Stacked prefixes (`^a ^b x`) accumulate, with the
+// prefix closer to the form taking precedence on overlapping keys.
+func metaMacro(rd *Reader, _ rune) (core.Value, error) {
+	pos := rd.position()
+	metaForm, err := rd.One()
+	if err != nil {
+		if err == io.EOF {
+			return nil, fmt.Errorf("reader: EOF while reading ^ metadata form: %w", ErrIncomplete)
+		}
+		return nil, err
+	}
+
+	meta, err := metaHashMap(metaForm, pos)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := rd.One()
+	if err != nil {
+		if err == io.EOF {
+			return nil, fmt.Errorf("reader: EOF while reading ^ target form: %w", ErrIncomplete)
+		}
+		return nil, err
+	}
+
+	m, ok := target.(core.IMeta)
+	if !ok {
+		return nil, fmt.Errorf("reader: cannot attach metadata to %s at %s", target, pos)
+	}
+
+	if existing := m.Meta(); existing != nil {
+		meta = mergeMeta(existing, meta)
+	}
+	return m.WithMeta(meta), nil
+}
+
+// metaHashMap converts the form following `^` into the map attached as
+// metadata, applying the documented shorthands.
+func metaHashMap(form core.Value, pos core.Position) (*core.HashMap, error) {
+	switch t := form.(type) {
+	case core.Keyword:
+		return &core.HashMap{Position: pos, Data: map[core.Value]core.Value{t: core.Bool(true)}}, nil
+	case core.String:
+		return &core.HashMap{Position: pos, Data: map[core.Value]core.Value{core.Keyword{Value: "tag"}: t}}, nil
+	case core.Symbol:
+		return &core.HashMap{Position: pos, Data: map[core.Value]core.Value{core.Keyword{Value: "tag"}: t}}, nil
+	case *core.HashMap:
+		return t, nil
+	default:
+		return nil, fmt.Errorf("reader: invalid metadata form %s at %s", form, pos)
+	}
+}
+
+// mergeMeta combines a target's existing metadata with metadata from a
+// further `^` prefix, with existing (i.e. the prefix closer to the
+// target) winning on overlapping keys.
+func mergeMeta(existing, outer *core.HashMap) *core.HashMap {
+	data := make(map[core.Value]core.Value, len(existing.Data)+len(outer.Data))
+	for k, v := range outer.Data {
+		data[k] = v
+	}
+	for k, v := range existing.Data {
+		data[k] = v
+	}
+	return &core.HashMap{Position: outer.Position, Data: data}
+}
+
+func quoteMacro(rd *Reader, _ rune) (core.Value, error) {
+	form, err := rd.One()
+	if err != nil {
+		if err == io.EOF {
+			return nil, fmt.Errorf("reader: EOF while reading quoted form: %w", ErrIncomplete)
+		}
+		return nil, err
+	}
+	return &core.List{Values: []core.Value{core.Symbol{Value: "quote"}, form}}, nil
+}
+
+func unquoteMacro(rd *Reader, _ rune) (core.Value, error) {
+	pos := rd.position()
+	splice := false
+	r, err := rd.NextRune()
+	switch {
+	case err == io.EOF:
+		// handled below when reading the form
+	case err != nil:
+		return nil, err
+	case r == '@':
+		splice = true
+	default:
+		rd.unreadRune()
+	}
+
+	form, err := rd.One()
+	if err != nil {
+		if err == io.EOF {
+			return nil, fmt.Errorf("reader: EOF while reading unquoted form: %w", ErrIncomplete)
+		}
+		return nil, err
+	}
+
+	if splice {
+		return core.UnquoteSplice{Value: form, Position: pos}, nil
+	}
+	return &core.List{Values: []core.Value{core.Symbol{Value: "unquote"}, form}}, nil
+}
+
+// syntaxQuoteMacro implements backtick (`) syntax-quote: it reads the
+// following form and walks it, quoting bare symbols, honoring ~ and ~@
+// escapes, and consistently renaming foo# auto-gensyms within this
+// syntax-quote's scope.
+func syntaxQuoteMacro(rd *Reader, _ rune) (core.Value, error) {
+	form, err := rd.One()
+	if err != nil {
+		if err == io.EOF {
+			return nil, fmt.Errorf("reader: EOF while reading syntax-quoted form: %w", ErrIncomplete)
+		}
+		return nil, err
+	}
+
+	return rd.syntaxQuote(form, map[string]string{})
+}
+
+func (rd *Reader) syntaxQuote(v core.Value, gensyms map[string]string) (core.Value, error) {
+	switch t := v.(type) {
+	case core.Symbol:
+		return quoteWrap(rd.gensymRename(t, gensyms)), nil
+
+	case *core.List:
+		if form, ok := unquotedForm(t); ok {
+			return form, nil
+		}
+		values, err := rd.syntaxQuoteSeq(t.Values, gensyms)
+		if err != nil {
+			return nil, err
+		}
+		return &core.List{Values: values, Position: t.Position}, nil
+
+	case core.Vector:
+		values, err := rd.syntaxQuoteSeq(t.Values, gensyms)
+		if err != nil {
+			return nil, err
+		}
+		return core.Vector{Values: values, Position: t.Position}, nil
+
+	case core.UnquoteSplice:
+		return nil, fmt.Errorf("reader: ~@ is only valid inside a list or vector")
+
+	default:
+		return v, nil
+	}
+}
+
+func (rd *Reader) syntaxQuoteSeq(vals []core.Value, gensyms map[string]string) ([]core.Value, error) {
+	var out []core.Value
+	for _, el := range vals {
+		if splice, ok := el.(core.UnquoteSplice); ok {
+			items, err := spliceItems(splice.Value, "~@")
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, items...)
+			continue
+		}
+
+		if lst, ok := el.(*core.List); ok {
+			if form, ok := unquotedForm(lst); ok {
+				out = append(out, form)
+				continue
+			}
+		}
+
+		walked, err := rd.syntaxQuote(el, gensyms)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, walked)
+	}
+	return out, nil
+}
+
+// unquotedForm reports whether lst is the (unquote form) wrapper
+// produced by reading a bare ~form, returning the unwrapped form.
+func unquotedForm(lst *core.List) (core.Value, bool) {
+	if len(lst.Values) != 2 {
+		return nil, false
+	}
+	sym, ok := lst.Values[0].(core.Symbol)
+	if !ok || sym.Value != "unquote" {
+		return nil, false
+	}
+	return lst.Values[1], true
+}
+
+// spliceItems returns the elements of v that a splicing form (~@ or
+// #?@) inlines into its enclosing list/vector. via names the operator,
+// for the error message.
+func spliceItems(v core.Value, via string) ([]core.Value, error) {
+	switch t := v.(type) {
+	case *core.List:
+		return t.Values, nil
+	case core.Vector:
+		return t.Values, nil
+	default:
+		return nil, fmt.Errorf("reader: cannot splice non-sequential form %s via %s", v, via)
+	}
+}
+
+// gensymRename rewrites a trailing-# symbol to a fresh, scope-unique
+// gensym, reusing the same suffix for repeated occurrences of the same
+// base name within this syntax-quote.
+func (rd *Reader) gensymRename(sym core.Symbol, gensyms map[string]string) core.Symbol {
+	if sym.Value == "#" || !strings.HasSuffix(sym.Value, "#") {
+		return sym
+	}
+
+	base := strings.TrimSuffix(sym.Value, "#")
+	name, ok := gensyms[base]
+	if !ok {
+		rd.gensymSeq++
+		name = fmt.Sprintf("%s__%d__auto__", base, rd.gensymSeq)
+		gensyms[base] = name
+	}
+	return core.Symbol{Value: name, Position: sym.Position}
+}
+
+func quoteWrap(v core.Value) core.Value {
+	return &core.List{Values: []core.Value{core.Symbol{Value: "quote"}, v}}
+}
+
+// condSplice is an internal marker produced by a matching `#?@` form.
+// readDelimited inlines its values into the collection being read, the
+// same way errSkip lets a macro produce no value at all.
+type condSplice struct {
+	values []core.Value
+}
+
+func (condSplice) String() string { return "#?@(...)" }
+
+// readerConditionalMacro implements `#?(:feature form ...)` and
+// `#?@(:feature form ...)` reader conditionals: it reads a list of
+// alternating feature-keyword/form pairs and yields the form for the
+// first keyword enabled in rd.Features, or errSkip if none match so
+// the surrounding read simply continues with whatever follows. The
+// `@` variant splices a sequential result into the enclosing
+// list/vector instead of inlining it as a single form.
+func readerConditionalMacro(rd *Reader, _ rune) (core.Value, error) {
+	splice := false
+	r, err := rd.NextRune()
+	switch {
+	case err == io.EOF:
+		return nil, fmt.Errorf("reader: EOF after '#?': %w", ErrIncomplete)
+	case err != nil:
+		return nil, err
+	case r == '@':
+		splice = true
+	default:
+		rd.unreadRune()
+	}
+
+	pos := rd.position()
+	form, err := rd.One()
+	if err != nil {
+		if err == io.EOF {
+			return nil, fmt.Errorf("reader: EOF while reading reader conditional: %w", ErrIncomplete)
+		}
+		return nil, err
+	}
+
+	lst, ok := form.(*core.List)
+	if !ok {
+		return nil, fmt.Errorf("reader: #? must be followed by a list at %s", pos)
+	}
+	if len(lst.Values)%2 != 0 {
+		return nil, fmt.Errorf("reader: odd number of forms in reader conditional at %s", pos)
+	}
+
+	var match core.Value
+	matched := false
+	for i := 0; i < len(lst.Values); i += 2 {
+		kw, ok := lst.Values[i].(core.Keyword)
+		if !ok {
+			return nil, fmt.Errorf("reader: reader conditional tag %s is not a keyword at %s", lst.Values[i], pos)
+		}
+		if !matched && rd.Features[kw.Value] {
+			match, matched = lst.Values[i+1], true
+		}
+	}
+	if !matched {
+		return nil, errSkip
+	}
+
+	if !splice {
+		return match, nil
+	}
+
+	items, err := spliceItems(match, "#?@")
+	if err != nil {
+		return nil, err
+	}
+	return condSplice{values: items}, nil
+}
+
+func closerMacro(_ *Reader, r rune) (core.Value, error) {
+	return nil, fmt.Errorf("reader: unexpected '%c'", r)
+}
+
+func commentMacro(rd *Reader, _ rune) (core.Value, error) {
+	for {
+		r, err := rd.NextRune()
+		if err == io.EOF {
+			return nil, errSkip
+		}
+		if err != nil {
+			return nil, err
+		}
+		if r == '\n' {
+			return nil, errSkip
+		}
+	}
+}
+
+func listMacro(rd *Reader, _ rune) (core.Value, error) {
+	pos := rd.position()
+	values, err := rd.readDelimited(')')
+	if err != nil {
+		return nil, err
+	}
+	return &core.List{Values: values, Position: pos}, nil
+}
+
+func vectorMacro(rd *Reader, _ rune) (core.Value, error) {
+	pos := rd.position()
+	values, err := rd.readDelimited(']')
+	if err != nil {
+		return nil, err
+	}
+	return core.Vector{Values: values, Position: pos}, nil
+}
+
+func hashMapMacro(rd *Reader, _ rune) (core.Value, error) {
+	pos := rd.position()
+	values, err := rd.readDelimited('}')
+	if err != nil {
+		return nil, err
+	}
+	if len(values)%2 != 0 {
+		return nil, fmt.Errorf("reader: odd number of forms in map at %s", pos)
+	}
+
+	data := map[core.Value]core.Value{}
+	for i := 0; i < len(values); i += 2 {
+		if !isHashable(values[i]) {
+			return nil, fmt.Errorf("reader: key %s is not hashable at %s", values[i], pos)
+		}
+		data[values[i]] = values[i+1]
+	}
+	return &core.HashMap{Data: data, Position: pos}, nil
+}
+
+// isHashable reports whether v can safely be used as a map key. List,
+// Vector, Set and HashMap all hold slices/maps internally and are not
+// comparable.
+func isHashable(v core.Value) bool {
+	switch t := v.(type) {
+	case *core.List, core.Vector, core.Set, *core.HashMap:
+		return false
+	case core.UnquoteSplice:
+		return isHashable(t.Value)
+	default:
+		return true
+	}
+}
+
+func setDispatchMacro(rd *Reader, _ rune) (core.Value, error) {
+	pos := rd.position()
+	values, err := rd.readDelimited('}')
+	if err != nil {
+		return nil, err
+	}
+
+	for i := 0; i < len(values); i++ {
+		for j := i + 1; j < len(values); j++ {
+			if reflect.DeepEqual(values[i], values[j]) {
+				return nil, fmt.Errorf("reader: duplicate value %s in set at %s", values[i], pos)
+			}
+		}
+	}
+
+	return core.Set{Values: values, Position: pos}, nil
+}
+
+func (rd *Reader) readDelimited(end rune) ([]core.Value, error) {
+	var values []core.Value
+	for {
+		r, err := rd.skipSpaces()
+		if err == io.EOF {
+			return nil, fmt.Errorf("reader: unexpected EOF, expected '%c': %w", end, ErrIncomplete)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if r == end {
+			return values, nil
+		}
+		if r == ')' || r == ']' || r == '}' {
+			return nil, fmt.Errorf("reader: unexpected '%c'", r)
+		}
+
+		rd.unreadRune()
+		v, err := rd.readOne()
+		if err == errSkip {
+			// e.g. a comment: it produced no value, so loop back to
+			// skipSpaces rather than treating the rune after it as
+			// part of a form already in progress.
+			continue
+		}
+		if err == io.EOF {
+			return nil, fmt.Errorf("reader: unexpected EOF, expected '%c': %w", end, ErrIncomplete)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if cs, ok := v.(condSplice); ok {
+			values = append(values, cs.values...)
+			continue
+		}
+		values = append(values, v)
+	}
+}
+
+func stringMacro(rd *Reader, _ rune) (core.Value, error) {
+	var sb strings.Builder
+	for {
+		r, err := rd.NextRune()
+		if err == io.EOF {
+			return nil, fmt.Errorf("reader: unexpected EOF, unterminated string: %w", ErrIncomplete)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if r == '"' {
+			return core.String(sb.String()), nil
+		}
+		if r == '\\' {
+			esc, err := rd.NextRune()
+			if err == io.EOF {
+				return nil, fmt.Errorf("reader: unexpected EOF in escape sequence: %w", ErrIncomplete)
+			}
+			if err != nil {
+				return nil, err
+			}
+			switch esc {
+			case '"':
+				sb.WriteRune('"')
+			case '\\':
+				sb.WriteRune('\\')
+			case 'n':
+				sb.WriteRune('\n')
+			case 't':
+				sb.WriteRune('\t')
+			case 'r':
+				sb.WriteRune('\r')
+			default:
+				return nil, fmt.Errorf("reader: invalid escape sequence '\\%c'", esc)
+			}
+			continue
+		}
+		sb.WriteRune(r)
+	}
+}
+
+var namedChars = map[string]rune{
+	"newline":   '\n',
+	"space":     ' ',
+	"tab":       '\t',
+	"formfeed":  '\f',
+	"backspace": '\b',
+	"return":    '\r',
+}
+
+func characterMacro(rd *Reader, _ rune) (core.Value, error) {
+	r, err := rd.NextRune()
+	if err == io.EOF {
+		return nil, fmt.Errorf("reader: EOF while reading character literal: %w", ErrIncomplete)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if r == 'u' {
+		token, err := rd.readToken('u')
+		if err != nil {
+			return nil, err
+		}
+		hex := strings.TrimPrefix(token, "u")
+		if hex != "" {
+			val, err := strconv.ParseInt(hex, 16, 32)
+			if err != nil || val < 0 {
+				return nil, fmt.Errorf("reader: invalid unicode character literal \\%s", token)
+			}
+			return core.Character(rune(val)), nil
+		}
+	}
+
+	if unicode.IsLetter(r) {
+		token, err := rd.readToken(r)
+		if err != nil {
+			return nil, err
+		}
+		if len([]rune(token)) == 1 {
+			return core.Character([]rune(token)[0]), nil
+		}
+		if named, ok := namedChars[token]; ok {
+			return core.Character(named), nil
+		}
+		return nil, fmt.Errorf("reader: unsupported character literal \\%s", token)
+	}
+
+	return core.Character(r), nil
+}
+
+func parseNumber(s string) (core.Value, error) {
+	neg := false
+	str := s
+	switch {
+	case strings.HasPrefix(str, "+"):
+		str = str[1:]
+	case strings.HasPrefix(str, "-"):
+		neg = true
+		str = str[1:]
+	}
+	if str == "" {
+		return nil, fmt.Errorf("not a number: %q", s)
+	}
+
+	if idx := strings.IndexAny(str, "rR"); idx > 0 && !strings.ContainsAny(str[:idx], ".") {
+		return parseRadix(str, idx, neg)
+	}
+
+	lower := strings.ToLower(str)
+	switch {
+	case strings.HasPrefix(lower, "0x"):
+		val, err := strconv.ParseInt(str[2:], 16, 64)
+		if err != nil {
+			return nil, err
+		}
+		return intValue(val, neg), nil
+	case strings.HasPrefix(lower, "0b"):
+		if strings.Contains(str, ".") {
+			return nil, fmt.Errorf("binary literal cannot have a decimal point: %q", s)
+		}
+		val, err := strconv.ParseInt(str[2:], 2, 64)
+		if err != nil {
+			return nil, err
+		}
+		return intValue(val, neg), nil
+	case strings.ContainsAny(str, ".eE"):
+		if strings.Count(str, ".") > 1 {
+			return nil, fmt.Errorf("too many decimal points: %q", s)
+		}
+		f, err := strconv.ParseFloat(str, 64)
+		if err != nil {
+			return nil, err
+		}
+		if neg {
+			f = -f
+		}
+		return core.Float64(f), nil
+	case strings.HasPrefix(str, "0") && len(str) > 1:
+		val, err := strconv.ParseInt(str, 8, 64)
+		if err != nil {
+			return nil, err
+		}
+		return intValue(val, neg), nil
+	default:
+		val, err := strconv.ParseInt(str, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return intValue(val, neg), nil
+	}
+}
+
+func parseRadix(str string, idx int, neg bool) (core.Value, error) {
+	basePart, digits := str[:idx], str[idx+1:]
+	if digits == "" || strings.ContainsAny(digits, "rR.") {
+		return nil, fmt.Errorf("invalid radix literal: %q", str)
+	}
+
+	base, err := strconv.Atoi(basePart)
+	if err != nil || base < 2 || base > 36 {
+		return nil, fmt.Errorf("invalid radix base: %q", basePart)
+	}
+
+	val, err := strconv.ParseInt(digits, base, 64)
+	if err != nil {
+		return nil, err
+	}
+	return intValue(val, neg), nil
+}
+
+func intValue(val int64, neg bool) core.Value {
+	if neg {
+		val = -val
+	}
+	return core.Int64(val)
+}
+
+func dispatchMacro(rd *Reader, _ rune) (core.Value, error) {
+	r, err := rd.NextRune()
+	if err == io.EOF {
+		return nil, fmt.Errorf("reader: EOF after '#': %w", ErrIncomplete)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if fn, ok := rd.dispatch[r]; ok {
+		return fn(rd, r)
+	}
+
+	rd.unreadRune()
+	return rd.readTaggedLiteral()
+}
+
+// readTaggedLiteral implements `#tag form` data-reader dispatch: it
+// reads the tag symbol, reads the following form, and hands the form
+// to the data-reader registered for tag.
+func (rd *Reader) readTaggedLiteral() (core.Value, error) {
+	pos := rd.position()
+
+	r, err := rd.NextRune()
+	if err == io.EOF {
+		return nil, fmt.Errorf("reader: EOF while reading tag: %w", ErrIncomplete)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	tag, err := rd.readToken(r)
+	if err != nil {
+		return nil, err
+	}
+
+	fn, ok := rd.dataReaders[tag]
+	if !ok {
+		return nil, fmt.Errorf("reader: no data-reader registered for tag '#%s' at %s", tag, pos)
+	}
+
+	form, err := rd.One()
+	if err != nil {
+		if err == io.EOF {
+			return nil, fmt.Errorf("reader: EOF while reading form for tag '#%s': %w", tag, ErrIncomplete)
+		}
+		return nil, err
+	}
+
+	return fn(rd, form)
+}
@@ -0,0 +1,101 @@
+package reader
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spy16/sabre/core"
+)
+
+func TestReader_ReaderConditional(t *testing.T) {
+	executeReaderTests(t, []readerTestCase{
+		{
+			name: "SelectsEnabledFeature",
+			src:  `#?(:jvm 1 :go 2)`,
+			want: core.Int64(2),
+		},
+		{
+			name: "FallsBackToDefault",
+			src:  `#?(:jvm 1 :default 2)`,
+			want: core.Int64(2),
+		},
+		{
+			name: "FirstMatchWins",
+			src:  `#?(:go 1 :default 2)`,
+			want: core.Int64(1),
+		},
+		{
+			name:    "OddNumberOfForms",
+			src:     `#?(:go 1 :default)`,
+			wantErr: true,
+		},
+		{
+			name:    "NonKeywordTag",
+			src:     `#?(go 1 :default 2)`,
+			wantErr: true,
+		},
+		{
+			name:    "NotFollowedByList",
+			src:     `#?1`,
+			wantErr: true,
+		},
+		{
+			name: "SpliceIntoList",
+			src:  `(a #?@(:go [1 2] :default [3]) b)`,
+			want: &core.List{
+				Values: []core.Value{
+					core.Symbol{Value: "a", Position: core.Position{File: "<string>", Line: 1, Column: 2}},
+					core.Int64(1),
+					core.Int64(2),
+					core.Symbol{Value: "b", Position: core.Position{File: "<string>", Line: 1, Column: 32}},
+				},
+				Position: core.Position{File: "<string>", Line: 1, Column: 1},
+			},
+		},
+		{
+			name: "SpliceIntoVector",
+			src:  `[a #?@(:go [1 2] :default [3]) b]`,
+			want: core.Vector{
+				Values: []core.Value{
+					core.Symbol{Value: "a", Position: core.Position{File: "<string>", Line: 1, Column: 2}},
+					core.Int64(1),
+					core.Int64(2),
+					core.Symbol{Value: "b", Position: core.Position{File: "<string>", Line: 1, Column: 32}},
+				},
+				Position: core.Position{File: "<string>", Line: 1, Column: 1},
+			},
+		},
+	})
+}
+
+func TestReader_ReaderConditional_Skipping(t *testing.T) {
+	rd := New(strings.NewReader(`#?(:jvm 1) 2`))
+
+	got, err := rd.One()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != core.Int64(2) {
+		t.Errorf("One() got = %#v, want 2 (the #? form should be skipped)", got)
+	}
+}
+
+func TestReader_ReaderConditional_SpliceOutsideCollection(t *testing.T) {
+	_, err := New(strings.NewReader(`#?@(:go [1 2])`)).One()
+	if err == nil {
+		t.Fatal("expected an error splicing outside a list/vector")
+	}
+}
+
+func TestReader_ReaderConditional_SetFeature(t *testing.T) {
+	rd := New(strings.NewReader(`#?(:custom 1 :default 2)`))
+	rd.SetFeature("custom", true)
+
+	got, err := rd.One()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != core.Int64(1) {
+		t.Errorf("One() got = %#v, want 1 after enabling :custom", got)
+	}
+}
@@ -0,0 +1,171 @@
+package reader
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spy16/sabre/core"
+)
+
+// slowReader returns one rune at a time regardless of the size of p,
+// simulating a source that trickles in over a connection.
+type slowReader struct {
+	rs []rune
+	i  int
+}
+
+func (sr *slowReader) Read(p []byte) (int, error) {
+	if sr.i >= len(sr.rs) {
+		return 0, io.EOF
+	}
+	n := copy(p, string(sr.rs[sr.i]))
+	sr.i++
+	return n, nil
+}
+
+func TestReader_Next(t *testing.T) {
+	t.Run("IncrementalFromSlowReader", func(t *testing.T) {
+		rd := New(&slowReader{rs: []rune(":a :b :c")})
+
+		var got []core.Value
+		for {
+			v, err := rd.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got = append(got, v)
+		}
+
+		want := []core.Value{
+			core.Keyword{Value: "a"},
+			core.Keyword{Value: "b"},
+			core.Keyword{Value: "c"},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Next() got = %#v, want = %#v", got, want)
+		}
+	})
+
+	t.Run("EOFAtEndOfStream", func(t *testing.T) {
+		rd := New(strings.NewReader(":a"))
+
+		if _, err := rd.Next(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if _, err := rd.Next(); err != io.EOF {
+			t.Errorf("Next() error = %#v, want io.EOF", err)
+		}
+	})
+
+	t.Run("MatchesAll", func(t *testing.T) {
+		const src = `:a (1 2) [3 4] "hello"`
+
+		mod, err := New(strings.NewReader(src)).All()
+		if err != nil {
+			t.Fatalf("All() unexpected error: %v", err)
+		}
+
+		var next core.Module
+		rd := New(strings.NewReader(src))
+		for {
+			v, err := rd.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("Next() unexpected error: %v", err)
+			}
+			next = append(next, v)
+		}
+
+		if !reflect.DeepEqual(mod, core.Value(next)) {
+			t.Errorf("Next() got = %#v, want = %#v", next, mod)
+		}
+	})
+}
+
+func TestReader_SnapshotRestore(t *testing.T) {
+	t.Run("RollbackOnParseFailureMidForm", func(t *testing.T) {
+		rd := New(strings.NewReader(`(1 2`))
+
+		snap := rd.Snapshot()
+
+		if _, err := rd.One(); !errors.Is(err, ErrIncomplete) {
+			t.Fatalf("One() error = %#v, want ErrIncomplete", err)
+		}
+
+		rd.Restore(snap)
+
+		// After restoring, the reader should be back at the start of
+		// the unterminated list and see the same error again.
+		if _, err := rd.One(); !errors.Is(err, ErrIncomplete) {
+			t.Errorf("One() after Restore() error = %#v, want ErrIncomplete", err)
+		}
+	})
+
+	t.Run("RestoreAfterPartialRead", func(t *testing.T) {
+		rd := New(strings.NewReader(`:a :b`))
+
+		if _, err := rd.Next(); err != nil {
+			t.Fatalf("Next() unexpected error: %v", err)
+		}
+
+		snap := rd.Snapshot()
+
+		v, err := rd.Next()
+		if err != nil {
+			t.Fatalf("Next() unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(v, core.Keyword{Value: "b"}) {
+			t.Fatalf("Next() got = %#v, want = :b", v)
+		}
+
+		rd.Restore(snap)
+
+		v, err = rd.Next()
+		if err != nil {
+			t.Fatalf("Next() after Restore() unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(v, core.Keyword{Value: "b"}) {
+			t.Errorf("Next() after Restore() got = %#v, want = :b", v)
+		}
+	})
+}
+
+func TestReader_ErrIncomplete(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+	}{
+		{name: "UnterminatedList", src: `(add 1 2`},
+		{name: "UnterminatedVector", src: `[1 2`},
+		{name: "UnterminatedString", src: `"hello`},
+		{name: "EOFAfterQuote", src: `'`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(strings.NewReader(tt.src)).One()
+			if !errors.Is(err, ErrIncomplete) {
+				t.Errorf("One() error = %#v, want wrapped ErrIncomplete", err)
+			}
+		})
+	}
+
+	t.Run("DistinctFromSyntaxError", func(t *testing.T) {
+		_, err := New(strings.NewReader(`)`)).One()
+		if err == nil {
+			t.Fatal("One() expected an error, got nil")
+		}
+		if errors.Is(err, ErrIncomplete) {
+			t.Errorf("One() error = %#v, want a non-ErrIncomplete syntax error", err)
+		}
+	})
+}
@@ -147,13 +147,13 @@ func TestReader_All(t *testing.T) {
 				core.Nil{},
 				core.Int64(10),
 				core.Character('a'),
-				core.Keyword("hello"),
+				core.Keyword{Value: "hello"},
 			},
 		},
 		{
 			name: "WithComment",
 			src:  `:valid-keyword ; comment should return errSkip`,
-			want: core.Module{core.Keyword("valid-keyword")},
+			want: core.Module{core.Keyword{Value: "valid-keyword"}},
 		},
 		{
 			name:    "UnterminatedString",
@@ -163,7 +163,7 @@ func TestReader_All(t *testing.T) {
 		{
 			name: "CommentFollowedByForm",
 			src:  `; comment should return errSkip` + "\n" + `:valid-keyword`,
-			want: core.Module{core.Keyword("valid-keyword")},
+			want: core.Module{core.Keyword{Value: "valid-keyword"}},
 		},
 		{
 			name:    "UnterminatedList",
@@ -449,27 +449,27 @@ func TestReader_One_Keyword(t *testing.T) {
 		{
 			name: "SimpleASCII",
 			src:  `:test`,
-			want: core.Keyword("test"),
+			want: core.Keyword{Value: "test"},
 		},
 		{
 			name: "LeadingTrailingSpaces",
 			src:  "          :test          ",
-			want: core.Keyword("test"),
+			want: core.Keyword{Value: "test"},
 		},
 		{
 			name: "SimpleUnicode",
 			src:  `:∂`,
-			want: core.Keyword("∂"),
+			want: core.Keyword{Value: "∂"},
 		},
 		{
 			name: "WithSpecialChars",
 			src:  `:this-is-valid?`,
-			want: core.Keyword("this-is-valid?"),
+			want: core.Keyword{Value: "this-is-valid?"},
 		},
 		{
 			name: "FollowedByMacroChar",
 			src:  `:this-is-valid'hello`,
-			want: core.Keyword("this-is-valid"),
+			want: core.Keyword{Value: "this-is-valid"},
 		},
 	})
 }
@@ -570,6 +570,25 @@ func TestReader_One_Symbol(t *testing.T) {
 	})
 }
 
+func TestReader_Symbol_MultiLinePositions(t *testing.T) {
+	rd := New(strings.NewReader("abc\ndef\nghi"))
+
+	want := []int{1, 2, 3}
+	for i, line := range want {
+		v, err := rd.One()
+		if err != nil {
+			t.Fatalf("One() #%d unexpected error: %v", i, err)
+		}
+		sym, ok := v.(core.Symbol)
+		if !ok {
+			t.Fatalf("One() #%d got = %#v, want core.Symbol", i, v)
+		}
+		if sym.Position.Line != line {
+			t.Errorf("One() #%d = %q, Position.Line = %d, want %d", i, sym.Value, sym.Position.Line, line)
+		}
+	}
+}
+
 func TestReader_One_List(t *testing.T) {
 	executeReaderTests(t, []readerTestCase{
 		{
@@ -904,8 +923,8 @@ func TestReader_One_HashMap(t *testing.T) {
 			want: &core.HashMap{
 				Position: core.Position{File: "<string>", Line: 1, Column: 1},
 				Data: map[core.Value]core.Value{
-					core.Keyword("age"):  core.Int64(10),
-					core.Keyword("name"): core.String("Bob"),
+					core.Keyword{Value: "age"}:  core.Int64(10),
+					core.Keyword{Value: "name"}: core.String("Bob"),
 				},
 			},
 		},
@@ -944,4 +963,4 @@ func executeReaderTests(t *testing.T, tests []readerTestCase) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,103 @@
+package reader
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spy16/sabre/core"
+)
+
+func TestReader_SyntaxQuote(t *testing.T) {
+	executeReaderTests(t, []readerTestCase{
+		{
+			name: "BareSymbol",
+			src:  "`foo",
+			want: quoteWrap(core.Symbol{
+				Value:    "foo",
+				Position: core.Position{File: "<string>", Line: 1, Column: 2},
+			}),
+		},
+		{
+			name: "Unquote",
+			src:  "`(a ~b c)",
+			want: &core.List{
+				Values: []core.Value{
+					quoteWrap(core.Symbol{Value: "a", Position: core.Position{File: "<string>", Line: 1, Column: 3}}),
+					core.Symbol{Value: "b", Position: core.Position{File: "<string>", Line: 1, Column: 6}},
+					quoteWrap(core.Symbol{Value: "c", Position: core.Position{File: "<string>", Line: 1, Column: 8}}),
+				},
+				Position: core.Position{File: "<string>", Line: 1, Column: 2},
+			},
+		},
+		{
+			name: "UnquoteSplicing",
+			src:  "`(a ~@[1 2] b)",
+			want: &core.List{
+				Values: []core.Value{
+					quoteWrap(core.Symbol{Value: "a", Position: core.Position{File: "<string>", Line: 1, Column: 3}}),
+					core.Int64(1),
+					core.Int64(2),
+					quoteWrap(core.Symbol{Value: "b", Position: core.Position{File: "<string>", Line: 1, Column: 13}}),
+				},
+				Position: core.Position{File: "<string>", Line: 1, Column: 2},
+			},
+		},
+	})
+}
+
+func TestReader_SyntaxQuote_Gensym(t *testing.T) {
+	got, err := New(strings.NewReader("`(foo# bar foo#)")).One()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lst, ok := got.(*core.List)
+	if !ok || len(lst.Values) != 3 {
+		t.Fatalf("got = %#v, want a 3 element list", got)
+	}
+
+	first := lst.Values[0].(*core.List).Values[1].(core.Symbol).Value
+	third := lst.Values[2].(*core.List).Values[1].(core.Symbol).Value
+	if first != third {
+		t.Errorf("repeated foo# occurrences got different suffixes: %q vs %q", first, third)
+	}
+	if !strings.HasPrefix(first, "foo__") || !strings.HasSuffix(first, "__auto__") {
+		t.Errorf("gensym = %q, want foo__N__auto__ pattern", first)
+	}
+}
+
+func TestReader_SyntaxQuote_NestedGensymScopes(t *testing.T) {
+	// The inner backtick is a fresh syntax-quote with its own gensym
+	// scope, so `foo#` there must not reuse the outer scope's suffix.
+	got, err := New(strings.NewReader("`(foo# `(foo#))")).One()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var gensyms []string
+	collectGensyms(got, &gensyms)
+
+	if len(gensyms) < 2 {
+		t.Fatalf("expected at least 2 gensym symbols, found %v", gensyms)
+	}
+	if gensyms[0] == gensyms[1] {
+		t.Errorf("inner and outer foo# should resolve to different gensyms, both got %q", gensyms[0])
+	}
+}
+
+func collectGensyms(v core.Value, out *[]string) {
+	switch t := v.(type) {
+	case core.Symbol:
+		if strings.HasPrefix(t.Value, "foo__") && strings.HasSuffix(t.Value, "__auto__") {
+			*out = append(*out, t.Value)
+		}
+	case *core.List:
+		for _, el := range t.Values {
+			collectGensyms(el, out)
+		}
+	case core.Vector:
+		for _, el := range t.Values {
+			collectGensyms(el, out)
+		}
+	}
+}